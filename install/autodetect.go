@@ -9,6 +9,8 @@ import (
 	"regexp"
 	"strings"
 
+	"github.com/blang/semver/v4"
+
 	"github.com/cilium/cilium-cli/k8s"
 )
 
@@ -17,7 +19,50 @@ type validationCheck interface {
 	Check(ctx context.Context, k *K8sInstaller) error
 }
 
+// CheckSeverity controls whether a failed validationCheck aborts autodetectAndValidate
+// (CheckSeverityError) or is merely surfaced to the user (CheckSeverityWarn).
+type CheckSeverity string
+
+const (
+	CheckSeverityError CheckSeverity = "error"
+	CheckSeverityWarn  CheckSeverity = "warn"
+)
+
+// CheckCategory groups validationChecks for the structured preflight report, e.g.
+// "kernel", "network", "rbac". It has no effect on whether a check runs.
+type CheckCategory string
+
+// severityProvider is implemented by validationChecks that want a severity other than the
+// default CheckSeverityError.
+type severityProvider interface {
+	Severity() CheckSeverity
+}
+
+// categoryProvider is implemented by validationChecks that want to tag themselves with a
+// CheckCategory in the structured preflight report.
+type categoryProvider interface {
+	Category() CheckCategory
+}
+
+func checkSeverity(c validationCheck) CheckSeverity {
+	if s, ok := c.(severityProvider); ok {
+		return s.Severity()
+	}
+	return CheckSeverityError
+}
+
+func checkCategory(c validationCheck) CheckCategory {
+	if cat, ok := c.(categoryProvider); ok {
+		return cat.Category()
+	}
+	return ""
+}
+
 var (
+	// validationChecks holds the validationChecks run per k8s.Kind during
+	// autodetectAndValidate and `cilium install preflight`. Flavor-specific checks
+	// (OpenShift, K3s, RKE2, ...) should live in their own files and register themselves
+	// via RegisterValidationCheck rather than growing this map directly.
 	validationChecks = map[k8s.Kind][]validationCheck{
 		k8s.KindMinikube: {
 			&minikubeVersionValidation{},
@@ -33,6 +78,13 @@ var (
 	clusterNameValidation = regexp.MustCompile(`^[a-z0-9]([-a-z0-9]*[a-z0-9])$`)
 )
 
+// RegisterValidationCheck adds a validationCheck to the set run for the given flavor. It is
+// intended to be called from an init() function so that flavor-specific checks can live in
+// their own files without editing the validationChecks map above.
+func RegisterValidationCheck(kind k8s.Kind, c validationCheck) {
+	validationChecks[kind] = append(validationChecks[kind], c)
+}
+
 func (p Parameters) checkDisabled(name string) bool {
 	for _, n := range p.DisableChecks {
 		if n == name {
@@ -42,6 +94,127 @@ func (p Parameters) checkDisabled(name string) bool {
 	return false
 }
 
+// checkAllowed reports whether name may run given --run-check. An empty RunChecks
+// allowlist (the default) permits every check.
+func (p Parameters) checkAllowed(name string) bool {
+	if len(p.RunChecks) == 0 {
+		return true
+	}
+	for _, n := range p.RunChecks {
+		if n == name {
+			return true
+		}
+	}
+	return false
+}
+
+// CheckResult is the structured outcome of a single validationCheck, suitable for the JSON
+// report produced by `cilium install preflight`.
+type CheckResult struct {
+	Name     string        `json:"name"`
+	Category CheckCategory `json:"category,omitempty"`
+	Severity CheckSeverity `json:"severity"`
+	Passed   bool          `json:"passed"`
+	Error    string        `json:"error,omitempty"`
+}
+
+// PreflightReport is the full set of CheckResults from one preflight run.
+type PreflightReport struct {
+	Kind   k8s.Kind      `json:"kind"`
+	Kinds  []k8s.Kind    `json:"kinds,omitempty"`
+	Checks []CheckResult `json:"checks"`
+}
+
+// resolveCheckKinds returns the set of k8s.Kind whose validationChecks should run. By
+// default this is just the autodetected k.flavor.Kind, but --include-kinds lets operators
+// on hybrid/federated clusters add the check sets of other flavors, and --exclude-kinds
+// drops kinds (including the autodetected one) from the result.
+func (k *K8sInstaller) resolveCheckKinds() []k8s.Kind {
+	kinds := append([]k8s.Kind{k.flavor.Kind}, k.params.IncludeKinds...)
+
+	excluded := make(map[k8s.Kind]struct{}, len(k.params.ExcludeKinds))
+	for _, kind := range k.params.ExcludeKinds {
+		excluded[kind] = struct{}{}
+	}
+
+	seen := make(map[k8s.Kind]struct{}, len(kinds))
+	result := make([]k8s.Kind, 0, len(kinds))
+	for _, kind := range kinds {
+		if _, skip := excluded[kind]; skip {
+			continue
+		}
+		if _, dup := seen[kind]; dup {
+			continue
+		}
+		seen[kind] = struct{}{}
+		result = append(result, kind)
+	}
+
+	return result
+}
+
+// runValidationChecks runs every enabled validationCheck registered for the kinds returned
+// by resolveCheckKinds and returns a PreflightReport alongside the first error-severity
+// failure, if any. CheckSeverityWarn failures are logged and recorded in the report but
+// never fail the run, and every check is attempted regardless of earlier failures so a
+// single invocation surfaces all problems instead of just the first one encountered.
+func (k *K8sInstaller) runValidationChecks(ctx context.Context) (*PreflightReport, error) {
+	kinds := k.resolveCheckKinds()
+	report := &PreflightReport{Kind: k.flavor.Kind, Kinds: kinds}
+
+	var checks []validationCheck
+	for _, kind := range kinds {
+		checks = append(checks, validationChecks[kind]...)
+	}
+	if len(checks) == 0 {
+		return report, nil
+	}
+
+	k.Log("✨ Running validation checks for kinds %v", kinds)
+
+	var firstErr error
+	for _, check := range checks {
+		name := check.Name()
+		if k.params.checkDisabled(name) || !k.params.checkAllowed(name) {
+			k.Log("⏭️  Skipping disabled validation test %q", name)
+			continue
+		}
+
+		result := CheckResult{
+			Name:     name,
+			Category: checkCategory(check),
+			Severity: checkSeverity(check),
+			Passed:   true,
+		}
+
+		if err := check.Check(ctx, k); err != nil {
+			result.Passed = false
+			result.Error = err.Error()
+
+			if result.Severity == CheckSeverityWarn {
+				k.Log("⚠️  Validation test %s failed: %s", name, err)
+			} else {
+				k.Log("❌ Validation test %s failed: %s", name, err)
+				k.Log("ℹ️  You can disable the test with --disable-check=%s", name)
+				if firstErr == nil {
+					firstErr = fmt.Errorf("validation check for kind %q failed: %w", k.flavor.Kind, err)
+				}
+			}
+		}
+
+		report.Checks = append(report.Checks, result)
+	}
+
+	return report, firstErr
+}
+
+// RunPreflight autodetects the cluster flavor and runs its validationChecks without
+// installing Cilium. It backs the `cilium install preflight` subcommand.
+func (k *K8sInstaller) RunPreflight(ctx context.Context) (*PreflightReport, error) {
+	k.autodetect(ctx)
+	return k.runValidationChecks(ctx)
+}
+
 func (k *K8sUninstaller) autodetect(ctx context.Context) {
 	k.flavor = k.client.AutodetectFlavor(ctx)
 
@@ -62,7 +235,7 @@ func (k *K8sInstaller) detectDatapathMode(ctx context.Context, withKPR bool) err
 
 		if withKPR && k.params.KubeProxyReplacement == "" {
 			k.Log("ℹ️  kube-proxy-replacement disabled")
-			k.params.KubeProxyReplacement = "disabled"
+			k.params.KubeProxyReplacement = "false"
 		}
 	case k8s.KindMinikube:
 		k.params.DatapathMode = DatapathTunnel
@@ -70,6 +243,41 @@ func (k *K8sInstaller) detectDatapathMode(ctx context.Context, withKPR bool) err
 		k.params.DatapathMode = DatapathAwsENI
 	case k8s.KindGKE:
 		k.params.DatapathMode = DatapathGKE
+	case k8s.KindOpenShift:
+		k.params.DatapathMode = DatapathTunnel
+		// OpenShift runs under SELinux/SCC by default; Cilium needs to be allowed to run
+		// privileged and to manage the host's CNI configuration.
+		k.params.HelmOpts.Values = append(k.params.HelmOpts.Values,
+			"securityContext.privileged=true",
+			"cni.binPath=/var/lib/cni/bin",
+			"cni.confPath=/etc/kubernetes/cni/net.d",
+		)
+
+		if withKPR && k.params.KubeProxyReplacement == "" {
+			k.Log("ℹ️  kube-proxy-replacement disabled")
+			k.params.KubeProxyReplacement = "false"
+		}
+	case k8s.KindK3s:
+		k.params.DatapathMode = DatapathTunnel
+
+		if withKPR && k.params.KubeProxyReplacement == "" {
+			if err := k.detectK3sServiceLBConflict(ctx); err != nil {
+				return err
+			}
+		}
+	case k8s.KindRKE2:
+		k.params.DatapathMode = DatapathTunnel
+
+		if withKPR && k.params.KubeProxyReplacement == "" {
+			k.Log("ℹ️  kube-proxy-replacement disabled")
+			k.params.KubeProxyReplacement = "false"
+		}
+	case k8s.KindTalos:
+		k.params.DatapathMode = DatapathTunnel
+		// Talos runs kubelet and the container runtime inside a minimal, immutable OS, so
+		// the agent needs to be told to run privileged up front rather than discovering it
+		// needs to retry once unprivileged.
+		k.params.HelmOpts.Values = append(k.params.HelmOpts.Values, "securityContext.privileged=true")
 	case k8s.KindAKS:
 		// When on AKS, we need to determine if the cluster is in BYOCNI mode before
 		// determining which DatapathMode to use.
@@ -86,7 +294,7 @@ func (k *K8sInstaller) detectDatapathMode(ctx context.Context, withKPR bool) err
 
 		if withKPR && k.params.KubeProxyReplacement == "" {
 			k.Log("ℹ️  kube-proxy-replacement disabled")
-			k.params.KubeProxyReplacement = "disabled"
+			k.params.KubeProxyReplacement = "false"
 		}
 	default:
 		k.params.DatapathMode = DatapathTunnel
@@ -98,6 +306,27 @@ func (k *K8sInstaller) detectDatapathMode(ctx context.Context, withKPR bool) err
 	return nil
 }
 
+// detectK3sServiceLBConflict disables kube-proxy-replacement on K3s only when the bundled
+// Traefik ingress or ServiceLB DaemonSets are present in kube-system, since both still
+// expect kube-proxy to be managing Services.
+func (k *K8sInstaller) detectK3sServiceLBConflict(ctx context.Context) error {
+	daemonSets, err := k.client.ListDaemonSets(ctx, "kube-system")
+	if err != nil {
+		return fmt.Errorf("unable to list daemonsets in namespace %q: %w", "kube-system", err)
+	}
+
+	for _, name := range daemonSets {
+		if name == "svclb-traefik" || name == "traefik" {
+			k.Log("ℹ️  Detected Traefik/ServiceLB, kube-proxy-replacement disabled")
+			k.params.KubeProxyReplacement = "false"
+			return nil
+		}
+	}
+
+	k.Log("ℹ️  No Traefik/ServiceLB conflict detected, leaving kube-proxy-replacement enabled")
+	return nil
+}
+
 func (k *K8sInstaller) autodetect(ctx context.Context) {
 	k.flavor = k.client.AutodetectFlavor(ctx)
 
@@ -106,24 +335,63 @@ func (k *K8sInstaller) autodetect(ctx context.Context) {
 	}
 }
 
+// legacyKubeProxyReplacementValues maps kube-proxy-replacement values accepted by Cilium
+// charts before they were collapsed down to "true"/"false". "strict", "partial", and
+// "probe" all enabled some degree of kube-proxy replacement (probe auto-detected what the
+// kernel supported and enabled it), so they all map to "true"; only "disabled" truly had
+// no replacement and maps to "false".
+var legacyKubeProxyReplacementValues = map[string]string{
+	"strict":   "true",
+	"partial":  "true",
+	"probe":    "true",
+	"disabled": "false",
+}
+
+// kubeProxyReplacementCollapseVersion is the first Cilium minor release whose charts reject
+// the legacy kube-proxy-replacement values (strict/disabled/probe/partial) in favor of
+// "true"/"false".
+var kubeProxyReplacementCollapseVersion = semver.MustParse("1.14.0")
+
+// chartSupportsLegacyKubeProxyReplacement reports whether k.chartVersion predates
+// kubeProxyReplacementCollapseVersion and therefore still accepts the legacy
+// kube-proxy-replacement values (strict/disabled/probe/partial). An unparsable
+// k.chartVersion is treated as a current chart, since that's the common case.
+func (k *K8sInstaller) chartSupportsLegacyKubeProxyReplacement() bool {
+	version, err := semver.ParseTolerant(k.chartVersion)
+	return err == nil && version.LT(kubeProxyReplacementCollapseVersion)
+}
+
+// translateKubeProxyReplacement rewrites a legacy kube-proxy-replacement value to its
+// true/false equivalent and logs a deprecation warning, but only once the detected chart
+// version requires it. Older charts still accept the legacy values, so the value is left
+// untouched when chartSupportsLegacyKubeProxyReplacement is true.
+func (k *K8sInstaller) translateKubeProxyReplacement() {
+	newValue, legacy := legacyKubeProxyReplacementValues[k.params.KubeProxyReplacement]
+	if !legacy || k.chartSupportsLegacyKubeProxyReplacement() {
+		return
+	}
+
+	if k.params.KubeProxyReplacement == "probe" {
+		k.Log("⚠️  kube-proxy-replacement value %q is deprecated, using %q instead (note: this enables kube-proxy-replacement rather than only probing kernel support for it)", k.params.KubeProxyReplacement, newValue)
+	} else {
+		k.Log("⚠️  kube-proxy-replacement value %q is deprecated, using %q instead", k.params.KubeProxyReplacement, newValue)
+	}
+	k.params.KubeProxyReplacement = newValue
+}
+
 func (k *K8sInstaller) autodetectAndValidate(ctx context.Context) error {
 	k.autodetect(ctx)
 
-	if len(validationChecks[k.flavor.Kind]) > 0 {
-		k.Log("✨ Running %q validation checks", k.flavor.Kind)
-		for _, check := range validationChecks[k.flavor.Kind] {
-			name := check.Name()
-			if k.params.checkDisabled(name) {
-				k.Log("⏭️  Skipping disabled validation test %q", name)
-				continue
-			}
+	// detectDatapathMode and the KPR translation run before the validation checks, so the
+	// checks see the resolved k.params.DatapathMode and k.params.KubeProxyReplacement.
+	if err := k.detectDatapathMode(ctx, true); err != nil {
+		return err
+	}
 
-			if err := check.Check(ctx, k); err != nil {
-				k.Log("❌ Validation test %s failed: %s", name, err)
-				k.Log("ℹ️  You can disable the test with --disable-check=%s", name)
-				return fmt.Errorf("validation check for kind %q failed: %w", k.flavor.Kind, err)
-			}
-		}
+	k.translateKubeProxyReplacement()
+
+	if _, err := k.runValidationChecks(ctx); err != nil {
+		return err
 	}
 
 	k.Log("ℹ️  Using Cilium version %s", k.chartVersion)
@@ -136,10 +404,6 @@ func (k *K8sInstaller) autodetectAndValidate(ctx context.Context) error {
 		}
 	}
 
-	if err := k.detectDatapathMode(ctx, true); err != nil {
-		return err
-	}
-
 	// TODO: remove when removing "ipam" flag (marked as deprecated), kept for
 	// backwards compatibility
 	if k.params.IPAM != "" {
@@ -166,5 +430,16 @@ func (k *K8sInstaller) autodetectAndValidate(ctx context.Context) error {
 		return fmt.Errorf("invalid encryption mode")
 	}
 
+	switch k.params.KubeProxyReplacement {
+	case "", "true", "false":
+		// nothing to do for valid values
+	default:
+		_, legacy := legacyKubeProxyReplacementValues[k.params.KubeProxyReplacement]
+		if !legacy || !k.chartSupportsLegacyKubeProxyReplacement() {
+			k.Log("❌ Invalid kube-proxy-replacement mode: %q", k.params.KubeProxyReplacement)
+			return fmt.Errorf("invalid kube-proxy-replacement mode")
+		}
+	}
+
 	return nil
 }
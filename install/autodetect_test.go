@@ -0,0 +1,92 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of Cilium
+
+package install
+
+import (
+	"context"
+	"testing"
+
+	"github.com/cilium/cilium-cli/k8s"
+)
+
+func TestParametersCheckDisabled(t *testing.T) {
+	p := Parameters{DisableChecks: []string{"minikube-version", "k8s-version"}}
+
+	if !p.checkDisabled("k8s-version") {
+		t.Error("expected k8s-version to be disabled")
+	}
+	if p.checkDisabled("kernel-features") {
+		t.Error("expected kernel-features not to be disabled")
+	}
+}
+
+func TestParametersCheckAllowed(t *testing.T) {
+	tests := []struct {
+		name      string
+		runChecks []string
+		check     string
+		want      bool
+	}{
+		{name: "empty allowlist permits everything", check: "k8s-version", want: true},
+		{name: "listed check is allowed", runChecks: []string{"k8s-version"}, check: "k8s-version", want: true},
+		{name: "unlisted check is rejected", runChecks: []string{"k8s-version"}, check: "kernel-features", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := Parameters{RunChecks: tt.runChecks}
+			if got := p.checkAllowed(tt.check); got != tt.want {
+				t.Errorf("checkAllowed(%q) = %v, want %v", tt.check, got, tt.want)
+			}
+		})
+	}
+}
+
+// fakeCheck is a minimal validationCheck used to exercise the optional
+// severityProvider/categoryProvider interfaces.
+type fakeCheck struct {
+	name     string
+	severity CheckSeverity
+	category CheckCategory
+}
+
+func (f *fakeCheck) Name() string                                 { return f.name }
+func (f *fakeCheck) Check(_ context.Context, _ *K8sInstaller) error { return nil }
+func (f *fakeCheck) Severity() CheckSeverity                       { return f.severity }
+func (f *fakeCheck) Category() CheckCategory                       { return f.category }
+
+// plainCheck implements only the base validationCheck interface, to exercise the default
+// severity/category fallback.
+type plainCheck struct{}
+
+func (p *plainCheck) Name() string                                 { return "plain" }
+func (p *plainCheck) Check(_ context.Context, _ *K8sInstaller) error { return nil }
+
+func TestCheckSeverityAndCategoryDefaults(t *testing.T) {
+	if got := checkSeverity(&plainCheck{}); got != CheckSeverityError {
+		t.Errorf("checkSeverity(plainCheck) = %q, want %q", got, CheckSeverityError)
+	}
+	if got := checkCategory(&plainCheck{}); got != "" {
+		t.Errorf("checkCategory(plainCheck) = %q, want empty", got)
+	}
+
+	custom := &fakeCheck{name: "fake", severity: CheckSeverityWarn, category: "network"}
+	if got := checkSeverity(custom); got != CheckSeverityWarn {
+		t.Errorf("checkSeverity(fakeCheck) = %q, want %q", got, CheckSeverityWarn)
+	}
+	if got := checkCategory(custom); got != "network" {
+		t.Errorf("checkCategory(fakeCheck) = %q, want %q", got, "network")
+	}
+}
+
+func TestRegisterValidationCheck(t *testing.T) {
+	const testKind = k8s.Kind("test-kind-for-register-check")
+	before := len(validationChecks[testKind])
+
+	RegisterValidationCheck(testKind, &plainCheck{})
+
+	if got := len(validationChecks[testKind]); got != before+1 {
+		t.Errorf("len(validationChecks[testKind]) = %d, want %d", got, before+1)
+	}
+}
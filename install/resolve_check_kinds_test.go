@@ -0,0 +1,59 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of Cilium
+
+package install
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/cilium/cilium-cli/k8s"
+)
+
+func TestResolveCheckKinds(t *testing.T) {
+	tests := []struct {
+		name    string
+		flavor  k8s.Kind
+		include []k8s.Kind
+		exclude []k8s.Kind
+		want    []k8s.Kind
+	}{
+		{
+			name:   "default is just the autodetected kind",
+			flavor: k8s.KindEKS,
+			want:   []k8s.Kind{k8s.KindEKS},
+		},
+		{
+			name:    "include adds extra kinds",
+			flavor:  k8s.KindEKS,
+			include: []k8s.Kind{k8s.KindGKE},
+			want:    []k8s.Kind{k8s.KindEKS, k8s.KindGKE},
+		},
+		{
+			name:    "exclude removes the autodetected kind",
+			flavor:  k8s.KindEKS,
+			exclude: []k8s.Kind{k8s.KindEKS},
+			want:    []k8s.Kind{},
+		},
+		{
+			name:    "duplicates between flavor and include are collapsed",
+			flavor:  k8s.KindEKS,
+			include: []k8s.Kind{k8s.KindEKS, k8s.KindGKE},
+			want:    []k8s.Kind{k8s.KindEKS, k8s.KindGKE},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			k := &K8sInstaller{
+				flavor: k8s.Flavor{Kind: tt.flavor},
+				params: Parameters{IncludeKinds: tt.include, ExcludeKinds: tt.exclude},
+			}
+
+			got := k.resolveCheckKinds()
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("resolveCheckKinds() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,105 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of Cilium
+
+package install
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/blang/semver/v4"
+
+	"github.com/cilium/cilium-cli/k8s"
+)
+
+func init() {
+	for _, kind := range []k8s.Kind{
+		k8s.KindUnknown, k8s.KindKind, k8s.KindMinikube, k8s.KindEKS, k8s.KindGKE, k8s.KindAKS,
+		k8s.KindOpenShift, k8s.KindK3s, k8s.KindRKE2, k8s.KindTalos,
+	} {
+		RegisterValidationCheck(kind, &kubernetesVersionValidation{})
+	}
+}
+
+// minimumKubernetesVersions maps a Cilium "major.minor" chart version to the oldest
+// Kubernetes server version its charts support. A chart minor missing from this table
+// falls back to defaultMinimumKubernetesVersion.
+var minimumKubernetesVersions = map[string]semver.Version{
+	"1.12": semver.MustParse("1.21.0"),
+	"1.13": semver.MustParse("1.22.0"),
+	"1.14": semver.MustParse("1.23.0"),
+	"1.15": semver.MustParse("1.24.0"),
+}
+
+// defaultMinimumKubernetesVersion is used for chart versions newer than anything in
+// minimumKubernetesVersions.
+var defaultMinimumKubernetesVersion = semver.MustParse("1.24.0")
+
+// kubernetesVersionValidation rejects installs against a Kubernetes server version older
+// than the minimum required by the detected Cilium chart. It is registered for every
+// flavor, since the requirement comes from the chart rather than from any one distro.
+type kubernetesVersionValidation struct{}
+
+func (k *kubernetesVersionValidation) Name() string {
+	return "k8s-version"
+}
+
+func (k *kubernetesVersionValidation) Check(ctx context.Context, installer *K8sInstaller) error {
+	raw, err := installer.client.GetServerVersion(ctx)
+	if err != nil {
+		return fmt.Errorf("unable to retrieve Kubernetes server version: %w", err)
+	}
+
+	version, err := parseKubernetesServerVersion(raw)
+	if err != nil {
+		return fmt.Errorf("unable to parse Kubernetes server version %q: %w", raw, err)
+	}
+
+	if len(version.Pre) > 0 && !installer.params.AllowPreReleaseK8sVersion {
+		return fmt.Errorf("Kubernetes server version %q is a pre-release, re-run with --allow-prerelease-k8s-version to proceed anyway", raw)
+	}
+
+	minimum := minimumKubernetesVersionFor(installer.chartVersion)
+	if version.LT(minimum) {
+		return fmt.Errorf("Kubernetes server version %q is older than the minimum %q required by Cilium %s", raw, minimum, installer.chartVersion)
+	}
+
+	return nil
+}
+
+// cloudProviderVersionSuffixes lists the non-semver build-metadata markers cloud providers
+// append to their Kubernetes server version, e.g. "v1.27.3-gke.1200" or
+// "v1.27.3-eks-abc1234". Each is cut, along with everything after it, before parsing. A
+// genuine pre-release marker like "-rc.1" doesn't match any of these and is left in place
+// so semver.Version.Pre can see it.
+var cloudProviderVersionSuffixes = []string{"-gke.", "-eks-", "-eks."}
+
+// parseKubernetesServerVersion parses a Kubernetes server version such as "v1.27.3", a
+// pre-release like "v1.30.0-rc.1", or a cloud-provider flavored one like
+// "v1.27.3-gke.1200"/"v1.27.3-eks-abc1234".
+func parseKubernetesServerVersion(raw string) (semver.Version, error) {
+	v := strings.TrimPrefix(raw, "v")
+	for _, suffix := range cloudProviderVersionSuffixes {
+		if idx := strings.Index(v, suffix); idx != -1 {
+			v = v[:idx]
+			break
+		}
+	}
+	return semver.ParseTolerant(v)
+}
+
+// minimumKubernetesVersionFor returns the oldest Kubernetes server version chartVersion's
+// charts support, falling back to defaultMinimumKubernetesVersion when chartVersion can't
+// be parsed or its minor isn't in minimumKubernetesVersions.
+func minimumKubernetesVersionFor(chartVersion string) semver.Version {
+	version, err := semver.ParseTolerant(strings.TrimPrefix(chartVersion, "v"))
+	if err != nil {
+		return defaultMinimumKubernetesVersion
+	}
+
+	if minimum, ok := minimumKubernetesVersions[fmt.Sprintf("%d.%d", version.Major, version.Minor)]; ok {
+		return minimum
+	}
+	return defaultMinimumKubernetesVersion
+}
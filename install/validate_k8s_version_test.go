@@ -0,0 +1,61 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of Cilium
+
+package install
+
+import "testing"
+
+func TestParseKubernetesServerVersion(t *testing.T) {
+	tests := []struct {
+		name    string
+		raw     string
+		want    string
+		wantPre bool
+	}{
+		{name: "plain", raw: "v1.27.3", want: "1.27.3"},
+		{name: "no v prefix", raw: "1.27.3", want: "1.27.3"},
+		{name: "gke suffix", raw: "v1.27.3-gke.1200", want: "1.27.3"},
+		{name: "eks dash suffix", raw: "v1.27.3-eks-abc1234", want: "1.27.3"},
+		{name: "eks dot suffix", raw: "v1.27.3-eks.5", want: "1.27.3"},
+		{name: "pre-release", raw: "v1.30.0-rc.1", want: "1.30.0-rc.1", wantPre: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseKubernetesServerVersion(tt.raw)
+			if err != nil {
+				t.Fatalf("parseKubernetesServerVersion(%q) returned error: %v", tt.raw, err)
+			}
+
+			if got.String() != tt.want {
+				t.Errorf("parseKubernetesServerVersion(%q) = %q, want %q", tt.raw, got.String(), tt.want)
+			}
+
+			if (len(got.Pre) > 0) != tt.wantPre {
+				t.Errorf("parseKubernetesServerVersion(%q) pre-release = %v, want %v", tt.raw, len(got.Pre) > 0, tt.wantPre)
+			}
+		})
+	}
+}
+
+func TestMinimumKubernetesVersionFor(t *testing.T) {
+	tests := []struct {
+		name         string
+		chartVersion string
+		want         string
+	}{
+		{name: "known minor", chartVersion: "1.13.4", want: "1.22.0"},
+		{name: "known minor with v prefix", chartVersion: "v1.14.0", want: "1.23.0"},
+		{name: "minor newer than table", chartVersion: "1.99.0", want: defaultMinimumKubernetesVersion.String()},
+		{name: "unparsable falls back to default", chartVersion: "not-a-version", want: defaultMinimumKubernetesVersion.String()},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := minimumKubernetesVersionFor(tt.chartVersion)
+			if got.String() != tt.want {
+				t.Errorf("minimumKubernetesVersionFor(%q) = %q, want %q", tt.chartVersion, got.String(), tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,184 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of Cilium
+
+package install
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/blang/semver/v4"
+
+	"github.com/cilium/cilium-cli/k8s"
+)
+
+func init() {
+	for _, kind := range []k8s.Kind{
+		k8s.KindUnknown, k8s.KindKind, k8s.KindMinikube, k8s.KindEKS, k8s.KindGKE, k8s.KindAKS,
+		k8s.KindOpenShift, k8s.KindK3s, k8s.KindRKE2, k8s.KindTalos,
+	} {
+		RegisterValidationCheck(kind, &kernelFeatureValidation{})
+	}
+}
+
+// minimumWireguardKernelVersion is the oldest upstream kernel with a built-in WireGuard
+// module; older kernels need it backported or loaded as a DKMS module, neither of which
+// this check can detect, so they're treated as missing the feature.
+var minimumWireguardKernelVersion = semver.MustParse("5.6.0")
+
+// requiredCgroupVersion is the cgroup hierarchy version Cilium's socket-based load
+// balancing and bandwidth manager depend on.
+const requiredCgroupVersion = "2"
+
+// strictRPFilter is the net.ipv4.conf.all.rp_filter value ("strict" reverse path
+// filtering) that drops traffic Cilium's eBPF datapath redirects between interfaces.
+const strictRPFilter = 1
+
+// NodeKernelFeatures is the result of probing a single node's kernel for the features
+// Cilium's datapath depends on.
+type NodeKernelFeatures struct {
+	Node          string   `json:"node"`
+	KernelVersion string   `json:"kernelVersion"`
+	BTFAvailable  bool     `json:"btfAvailable"`
+	CgroupVersion string   `json:"cgroupVersion"`
+	RPFilter      int      `json:"rpFilter"`
+	BPFJITEnable  bool     `json:"bpfJitEnable"`
+	XFRMSupported bool     `json:"xfrmSupported"`
+	Missing       []string `json:"missing,omitempty"`
+}
+
+// kernelFeatureValidation launches a diagnostic pod per node to probe kernel version,
+// BPF/BTF availability, cgroup version, and the sysctls Cilium's datapath depends on, then
+// compares the result against what k.params.Encryption requires plus a fixed set of
+// requirements (BTF, cgroup v2, non-strict rp_filter, BPF JIT) that apply to every
+// datapath mode; k.params.DatapathMode is not consulted.
+type kernelFeatureValidation struct{}
+
+func (k *kernelFeatureValidation) Name() string {
+	return "kernel-features"
+}
+
+func (k *kernelFeatureValidation) Check(ctx context.Context, installer *K8sInstaller) error {
+	results, err := installer.client.ProbeNodeKernelFeatures(ctx)
+	if err != nil {
+		return fmt.Errorf("unable to probe node kernel features: %w", err)
+	}
+
+	for i := range results {
+		results[i].Missing = missingKernelFeatures(&results[i], installer.params)
+	}
+
+	// If any node fails the WireGuard kernel requirement and --auto-fallback is set, drop
+	// that failure, fall back to IPsec and re-evaluate against IPsec's requirement. Other
+	// failures (e.g. missing BTF) are kept and still fail the install.
+	if installer.params.AutoFallback && installer.params.Encryption == encryptionWireguard {
+		fellBack := false
+		for i := range results {
+			if removeWireguardKernelGap(&results[i]) {
+				fellBack = true
+			}
+		}
+
+		if fellBack {
+			installer.Log("⚠️  WireGuard is unsupported on some nodes, falling back to IPsec")
+			installer.params.Encryption = encryptionIPsec
+
+			for i := range results {
+				if gap := ipsecKernelGap(&results[i]); gap != "" {
+					results[i].Missing = append(results[i].Missing, gap)
+				}
+			}
+		}
+	}
+
+	installer.kernelFeatures = results
+
+	var failing []string
+	for _, node := range results {
+		if len(node.Missing) > 0 {
+			failing = append(failing, fmt.Sprintf("%s: %s", node.Node, strings.Join(node.Missing, ", ")))
+		}
+	}
+
+	if len(failing) == 0 {
+		return nil
+	}
+
+	return fmt.Errorf("nodes are missing required kernel features: %s", strings.Join(failing, "; "))
+}
+
+// wireguardKernelGap returns the WireGuard kernel-version failure message for node, or ""
+// if its kernel is new enough. The "wireguard:" prefix lets removeWireguardKernelGap find
+// and neutralize this specific failure on --auto-fallback without touching unrelated ones.
+func wireguardKernelGap(node *NodeKernelFeatures) string {
+	if version, err := semver.ParseTolerant(node.KernelVersion); err == nil && !version.LT(minimumWireguardKernelVersion) {
+		return ""
+	}
+	return fmt.Sprintf("wireguard: kernel %s older than %s required for WireGuard", node.KernelVersion, minimumWireguardKernelVersion)
+}
+
+// removeWireguardKernelGap strips the WireGuard kernel-version failure, if present, from
+// node.Missing and reports whether it found one.
+func removeWireguardKernelGap(node *NodeKernelFeatures) bool {
+	original := node.Missing
+	kept := make([]string, 0, len(original))
+	removed := false
+	for _, entry := range original {
+		if strings.HasPrefix(entry, "wireguard:") {
+			removed = true
+			continue
+		}
+		kept = append(kept, entry)
+	}
+	node.Missing = kept
+	return removed
+}
+
+// ipsecKernelGap returns the XFRM failure message for node, or "" if it has the XFRM
+// support IPsec needs.
+func ipsecKernelGap(node *NodeKernelFeatures) string {
+	if node.XFRMSupported {
+		return ""
+	}
+	return "ipsec: XFRM support required for IPsec"
+}
+
+// missingKernelFeatures reports which of node's features fall short of what params'
+// Encryption requires and of the requirements shared by every datapath mode. It does not
+// look at params.DatapathMode.
+func missingKernelFeatures(node *NodeKernelFeatures, params Parameters) []string {
+	var missing []string
+
+	if params.Encryption == encryptionWireguard {
+		if gap := wireguardKernelGap(node); gap != "" {
+			missing = append(missing, gap)
+		}
+	}
+
+	if params.Encryption == encryptionIPsec {
+		if gap := ipsecKernelGap(node); gap != "" {
+			missing = append(missing, gap)
+		}
+	}
+
+	// CO-RE eBPF program loading needs BTF regardless of datapath mode, not just on the
+	// flavors that happen to ship BTF-less kernels most often.
+	if !node.BTFAvailable {
+		missing = append(missing, "BTF support")
+	}
+
+	if node.CgroupVersion != requiredCgroupVersion {
+		missing = append(missing, fmt.Sprintf("cgroup v%s required for socket-based load balancing, found cgroup v%s", requiredCgroupVersion, node.CgroupVersion))
+	}
+
+	if node.RPFilter == strictRPFilter {
+		missing = append(missing, "net.ipv4.conf.all.rp_filter=1 (strict) drops Cilium-redirected traffic, set to 0 or 2")
+	}
+
+	if !node.BPFJITEnable {
+		missing = append(missing, "net.core.bpf_jit_enable must be enabled")
+	}
+
+	return missing
+}
@@ -0,0 +1,114 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of Cilium
+
+package install
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestMissingKernelFeatures(t *testing.T) {
+	goodNode := NodeKernelFeatures{
+		Node:          "node-1",
+		KernelVersion: "5.15.0",
+		BTFAvailable:  true,
+		CgroupVersion: requiredCgroupVersion,
+		RPFilter:      0,
+		BPFJITEnable:  true,
+		XFRMSupported: true,
+	}
+
+	tests := []struct {
+		name    string
+		node    NodeKernelFeatures
+		params  Parameters
+		wantLen int
+	}{
+		{name: "fully compliant node has no gaps", node: goodNode, wantLen: 0},
+		{
+			name:    "old kernel fails wireguard requirement",
+			node:    func() NodeKernelFeatures { n := goodNode; n.KernelVersion = "5.4.0"; return n }(),
+			params:  Parameters{Encryption: encryptionWireguard},
+			wantLen: 1,
+		},
+		{
+			name:    "missing XFRM fails ipsec requirement",
+			node:    func() NodeKernelFeatures { n := goodNode; n.XFRMSupported = false; return n }(),
+			params:  Parameters{Encryption: encryptionIPsec},
+			wantLen: 1,
+		},
+		{
+			name:    "missing BTF fails regardless of datapath mode",
+			node:    func() NodeKernelFeatures { n := goodNode; n.BTFAvailable = false; return n }(),
+			params:  Parameters{DatapathMode: DatapathTunnel},
+			wantLen: 1,
+		},
+		{
+			name:    "cgroup v1 fails",
+			node:    func() NodeKernelFeatures { n := goodNode; n.CgroupVersion = "1"; return n }(),
+			wantLen: 1,
+		},
+		{
+			name:    "strict rp_filter fails",
+			node:    func() NodeKernelFeatures { n := goodNode; n.RPFilter = strictRPFilter; return n }(),
+			wantLen: 1,
+		},
+		{
+			name:    "bpf_jit_enable disabled fails",
+			node:    func() NodeKernelFeatures { n := goodNode; n.BPFJITEnable = false; return n }(),
+			wantLen: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := missingKernelFeatures(&tt.node, tt.params)
+			if len(got) != tt.wantLen {
+				t.Errorf("missingKernelFeatures() = %v, want %d entries", got, tt.wantLen)
+			}
+		})
+	}
+}
+
+func TestRemoveWireguardKernelGap(t *testing.T) {
+	tests := []struct {
+		name        string
+		missing     []string
+		wantRemoved bool
+		wantMissing []string
+	}{
+		{
+			name:        "no gaps",
+			missing:     nil,
+			wantRemoved: false,
+			wantMissing: []string{},
+		},
+		{
+			name:        "removes only the wireguard gap",
+			missing:     []string{"wireguard: kernel 5.4.0 older than 5.6.0 required for WireGuard", "BTF support"},
+			wantRemoved: true,
+			wantMissing: []string{"BTF support"},
+		},
+		{
+			name:        "leaves unrelated gaps alone when no wireguard gap present",
+			missing:     []string{"BTF support"},
+			wantRemoved: false,
+			wantMissing: []string{"BTF support"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			node := &NodeKernelFeatures{Missing: tt.missing}
+			removed := removeWireguardKernelGap(node)
+
+			if removed != tt.wantRemoved {
+				t.Errorf("removeWireguardKernelGap() removed = %v, want %v", removed, tt.wantRemoved)
+			}
+			if !reflect.DeepEqual(node.Missing, tt.wantMissing) {
+				t.Errorf("node.Missing = %v, want %v", node.Missing, tt.wantMissing)
+			}
+		})
+	}
+}
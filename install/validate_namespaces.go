@@ -0,0 +1,93 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of Cilium
+
+package install
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/cilium/cilium-cli/k8s"
+)
+
+func init() {
+	for _, kind := range []k8s.Kind{
+		k8s.KindUnknown, k8s.KindKind, k8s.KindMinikube, k8s.KindEKS, k8s.KindGKE, k8s.KindAKS,
+		k8s.KindOpenShift, k8s.KindK3s, k8s.KindRKE2, k8s.KindTalos,
+	} {
+		RegisterValidationCheck(kind, &conflictingCNIValidation{})
+	}
+}
+
+// knownCNIDaemonSets maps the well-known DaemonSet name of another CNI to a human-friendly
+// name, for the error message in conflictingCNIValidation.
+var knownCNIDaemonSets = map[string]string{
+	"calico-node":     "Calico",
+	"kube-flannel-ds": "Flannel",
+	"weave-net":       "Weave Net",
+}
+
+// conflictingCNIValidation scans namespaces for DaemonSets belonging to another CNI that
+// would conflict with installing Cilium. The namespaces scanned are restricted by
+// --include-namespaces/--exclude-namespaces via K8sInstaller.namespacesToScan.
+type conflictingCNIValidation struct{}
+
+func (c *conflictingCNIValidation) Name() string {
+	return "conflicting-cni"
+}
+
+func (c *conflictingCNIValidation) Check(ctx context.Context, k *K8sInstaller) error {
+	namespaces, err := k.namespacesToScan(ctx)
+	if err != nil {
+		return fmt.Errorf("unable to list namespaces to scan: %w", err)
+	}
+
+	for _, ns := range namespaces {
+		daemonSets, err := k.client.ListDaemonSets(ctx, ns)
+		if err != nil {
+			return fmt.Errorf("unable to list daemonsets in namespace %q: %w", ns, err)
+		}
+
+		for _, name := range daemonSets {
+			if cni, known := knownCNIDaemonSets[name]; known {
+				return fmt.Errorf("found %s DaemonSet %q in namespace %q, which conflicts with Cilium", cni, name, ns)
+			}
+		}
+	}
+
+	return nil
+}
+
+// namespacesToScan returns the namespaces the namespace-scanning validationChecks should
+// examine: every cluster namespace by default, narrowed to --include-namespaces when set,
+// and always trimmed by --exclude-namespaces.
+func (k *K8sInstaller) namespacesToScan(ctx context.Context) ([]string, error) {
+	namespaces := k.params.IncludeNamespaces
+	if len(namespaces) == 0 {
+		all, err := k.client.ListNamespaces(ctx)
+		if err != nil {
+			return nil, err
+		}
+		namespaces = all
+	}
+
+	return excludeNamespaces(namespaces, k.params.ExcludeNamespaces), nil
+}
+
+// excludeNamespaces returns namespaces with every entry also present in excluded removed.
+func excludeNamespaces(namespaces, excluded []string) []string {
+	excludedSet := make(map[string]struct{}, len(excluded))
+	for _, ns := range excluded {
+		excludedSet[ns] = struct{}{}
+	}
+
+	result := make([]string, 0, len(namespaces))
+	for _, ns := range namespaces {
+		if _, skip := excludedSet[ns]; skip {
+			continue
+		}
+		result = append(result, ns)
+	}
+
+	return result
+}
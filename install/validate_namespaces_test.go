@@ -0,0 +1,45 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of Cilium
+
+package install
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestExcludeNamespaces(t *testing.T) {
+	tests := []struct {
+		name       string
+		namespaces []string
+		excluded   []string
+		want       []string
+	}{
+		{
+			name:       "no exclusions",
+			namespaces: []string{"default", "kube-system"},
+			want:       []string{"default", "kube-system"},
+		},
+		{
+			name:       "excludes a match",
+			namespaces: []string{"default", "kube-system", "kube-public"},
+			excluded:   []string{"kube-system"},
+			want:       []string{"default", "kube-public"},
+		},
+		{
+			name:       "excluding everything yields empty, non-nil slice",
+			namespaces: []string{"default"},
+			excluded:   []string{"default"},
+			want:       []string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := excludeNamespaces(tt.namespaces, tt.excluded)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("excludeNamespaces(%v, %v) = %v, want %v", tt.namespaces, tt.excluded, got, tt.want)
+			}
+		})
+	}
+}